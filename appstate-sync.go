@@ -0,0 +1,34 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oussama-chaabouni/whatsmeow/appstate"
+)
+
+// FetchAppState downloads the latest patches for the given app state type, decodes them into
+// mutations and routes every one of them through dispatchAppState, the same way the official apps
+// apply a patch they've just downloaded. fullSync requests a patch from scratch instead of just
+// the patches since the last known version; PostPairSync sets it after a fresh pairing, since
+// there's no previous version to diff against yet.
+func (cli *Client) FetchAppState(ctx context.Context, name appstate.WAPatchName, fullSync, onlyIfNotSynced bool) error {
+	patches, err := cli.downloadAppStatePatches(ctx, name, fullSync, onlyIfNotSynced)
+	if err != nil {
+		return fmt.Errorf("failed to download app state patches for %s: %w", name, err)
+	}
+	mutations, err := cli.appStateProc.DecodePatches(name, patches, fullSync)
+	if err != nil {
+		return fmt.Errorf("failed to decode app state patches for %s: %w", name, err)
+	}
+	for _, mutation := range mutations {
+		cli.dispatchAppState(mutation, fullSync)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package appstate
+
+import (
+	"google.golang.org/protobuf/proto"
+
+	"github.com/oussama-chaabouni/whatsmeow/proto/waSyncAction"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+)
+
+// Index names for WhatsApp Business label mutations.
+const (
+	IndexLabelEdit               = "label_edit"
+	IndexLabelAssociationChat    = "label_jid"
+	IndexLabelAssociationMessage = "label_message"
+)
+
+// BuildLabelEdit creates an app state patch that creates, renames or recolors a label, or deletes
+// it if deleted is true. labelID is chosen by the caller: the official apps use a small integer
+// as a string, but any value that's unique per label works.
+func BuildLabelEdit(labelID, name string, color int32, deleted bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegularHigh,
+		Mutations: []MutationInfo{
+			{
+				Index:   []string{IndexLabelEdit, labelID},
+				Version: 3,
+				Value: &waSyncAction.SyncActionValue{
+					LabelEditAction: &waSyncAction.LabelEditAction{
+						Name:    proto.String(name),
+						Color:   proto.Int32(color),
+						Deleted: proto.Bool(deleted),
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildLabelChatAction creates an app state patch that adds or removes a label on a chat.
+func BuildLabelChatAction(chat types.JID, labelID string, labeled bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegularHigh,
+		Mutations: []MutationInfo{
+			{
+				Index:   []string{IndexLabelAssociationChat, labelID, chat.String()},
+				Version: 3,
+				Value: &waSyncAction.SyncActionValue{
+					LabelAssociationAction: &waSyncAction.LabelAssociationAction{
+						Labeled: proto.Bool(labeled),
+					},
+				},
+			},
+		},
+	}
+}
+
+// BuildLabelMessageAction creates an app state patch that adds or removes a label on a single
+// message within a chat.
+func BuildLabelMessageAction(chat types.JID, messageID types.MessageID, labelID string, labeled bool) PatchInfo {
+	return PatchInfo{
+		Type: WAPatchRegularHigh,
+		Mutations: []MutationInfo{
+			{
+				Index:   []string{IndexLabelAssociationMessage, chat.String(), messageID, labelID},
+				Version: 3,
+				Value: &waSyncAction.SyncActionValue{
+					LabelAssociationAction: &waSyncAction.LabelAssociationAction{
+						Labeled: proto.Bool(labeled),
+					},
+				},
+			},
+		},
+	}
+}
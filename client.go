@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/oussama-chaabouni/whatsmeow/store"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	waLog "github.com/oussama-chaabouni/whatsmeow/util/log"
+)
+
+// PrePairCallback is called before a pairing is completed. If it returns false, the pairing is
+// cancelled and the client disconnects.
+type PrePairCallback func(jid types.JID, platform, businessName string) bool
+
+// Client is a WhatsApp client for the multidevice API.
+type Client struct {
+	Store *store.Device
+	Log   waLog.Logger
+
+	PrePairCallback PrePairCallback
+
+	// phonePairingStateLock guards phonePairingState, which holds the key material for a pending
+	// PairPhone/PairHosted call between the request and the matching pair-success IQ (see
+	// pair-code.go).
+	phonePairingStateLock sync.Mutex
+	phonePairingState     *phonePairingState
+
+	// hostedPairPending is set while a PairHosted call is waiting on its pair-success/pair-error
+	// IQ, so handlePairSuccess knows to dispatch the Hosted* variant of the pairing events on
+	// failure (see pendingHostedPair in hosted.go).
+	hostedPairPending atomic.Bool
+
+	// hostedPeersLock guards hostedPeers, the set of peer JIDs known to belong to hosted
+	// accounts (see isHostedPeer/MarkHostedPeer in hosted.go).
+	hostedPeersLock sync.RWMutex
+	hostedPeers     map[types.JID]bool
+
+	// EnableAutoPostPairSync controls whether maybeStartPostPairSync runs PostPairSync
+	// automatically once this client reconnects after a successful pairing (see pair-sync.go).
+	EnableAutoPostPairSync bool
+	// PostPairSyncTimeout overrides DefaultPostPairSyncTimeout for that automatic post-pair sync.
+	PostPairSyncTimeout time.Duration
+
+	// eventHandlersLock guards eventHandlers and lastHandlerID, which back
+	// AddEventHandler/RemoveEventHandler/dispatchEvent (see event.go).
+	eventHandlersLock sync.RWMutex
+	eventHandlers     []wrappedEventHandler
+	lastHandlerID     uint32
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+// EventHandler is the signature for a callback registered with AddEventHandler. Handlers are
+// expected to type-switch on evt for the event types they care about (events.PairSuccess,
+// events.LabelEdit, events.IGMessage, ...), the same way every dispatchEvent caller in this
+// package produces those concrete pointer types.
+type EventHandler func(evt interface{})
+
+type wrappedEventHandler struct {
+	fn EventHandler
+	id uint32
+}
+
+// AddEventHandler registers fn to be called for every event this client dispatches, in the order
+// it was added relative to other handlers, and returns an ID that can be passed to
+// RemoveEventHandler to unregister it again.
+func (cli *Client) AddEventHandler(fn EventHandler) uint32 {
+	cli.eventHandlersLock.Lock()
+	defer cli.eventHandlersLock.Unlock()
+	cli.lastHandlerID++
+	id := cli.lastHandlerID
+	cli.eventHandlers = append(cli.eventHandlers, wrappedEventHandler{fn: fn, id: id})
+	return id
+}
+
+// RemoveEventHandler removes a previously registered event handler by the ID AddEventHandler
+// returned for it, e.g. the one-shot events.Connected handler maybeStartPostPairSync registers. It
+// reports whether a handler with that ID was found.
+func (cli *Client) RemoveEventHandler(id uint32) bool {
+	cli.eventHandlersLock.Lock()
+	defer cli.eventHandlersLock.Unlock()
+	for i, wrapped := range cli.eventHandlers {
+		if wrapped.id == id {
+			cli.eventHandlers = append(cli.eventHandlers[:i], cli.eventHandlers[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// dispatchEvent calls every handler registered with AddEventHandler with evt, in the order they
+// were added. It's the single place every event this client produces flows through.
+func (cli *Client) dispatchEvent(evt interface{}) {
+	cli.eventHandlersLock.RLock()
+	handlers := make([]wrappedEventHandler, len(cli.eventHandlers))
+	copy(handlers, cli.eventHandlers)
+	cli.eventHandlersLock.RUnlock()
+	for _, wrapped := range handlers {
+		wrapped.fn(evt)
+	}
+}
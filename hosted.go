@@ -0,0 +1,98 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+
+	waBinary "github.com/oussama-chaabouni/whatsmeow/binary"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+)
+
+// isHostedAccount reports whether this client's own device is linked to a hosted (WhatsApp
+// Business Cloud API) account, as opposed to a regular WhatsApp account. It's the single source
+// of truth for isHostedAccount outside of the pairing moment itself; see ResignDeviceIdentity for
+// the re-signing-on-key-rotation case.
+func (cli *Client) isHostedAccount() bool {
+	return cli.Store.AccountType == types.AccountTypeHosted
+}
+
+// ResignDeviceIdentity re-derives and stores this client's own device signature, using the hosted
+// or regular prefix according to cli.Store.AccountType. handlePair already does this once at
+// pairing time; callers that rotate the identity key afterwards (key rotation, re-registration,
+// etc.) must call this again so the signature keeps matching cli.isHostedAccount(), since
+// generateDeviceSignature has no other way to know which prefix applies.
+func (cli *Client) ResignDeviceIdentity(ctx context.Context) error {
+	if cli.Store.Account == nil {
+		return fmt.Errorf("no device identity to re-sign")
+	}
+	cli.Store.Account.DeviceSignature = generateDeviceSignature(cli.Store.Account, cli.Store.IdentityKey, cli.isHostedAccount())[:]
+	return cli.Store.Save(ctx)
+}
+
+// pendingHostedPair reports whether the pair-success/pair-error IQ currently being handled by
+// handlePair belongs to a PairHosted call, so handlePairSuccess knows to dispatch
+// events.HostedPairError instead of events.PairError on failure. On success, cli.isHostedAccount
+// is used instead, since Store.AccountType is set by then.
+func (cli *Client) pendingHostedPair() bool {
+	return cli.hostedPairPending.Load()
+}
+
+// isHostedPeer reports whether the given JID belongs to a hosted (WhatsApp Business Cloud API)
+// account, so that outgoing encryption to it can use the hosted device-identity signature
+// prefixes (AdvHostedPrefixDeviceIdentityAccountSignature /
+// AdvHostedPrefixDeviceIdentityDeviceSignatureVerification) instead of the regular ones. It's
+// used by sendMessageTransport (message-transport.go) to pick the right prefix for every outgoing
+// message, regardless of which message application is being sent.
+func (cli *Client) isHostedPeer(jid types.JID) bool {
+	cli.hostedPeersLock.RLock()
+	defer cli.hostedPeersLock.RUnlock()
+	return cli.hostedPeers[jid.ToNonAD()]
+}
+
+// MarkHostedPeer records that jid is a hosted-account peer, so sendMessageTransport uses the
+// hosted signature prefixes for future sends to it. There's nothing in a regular message stanza
+// that flags the sender as hosted, so callers that learn this out of band (e.g. from WhatsApp
+// Business Cloud API directory metadata) must call this themselves before messaging that peer.
+func (cli *Client) MarkHostedPeer(jid types.JID) {
+	cli.hostedPeersLock.Lock()
+	defer cli.hostedPeersLock.Unlock()
+	if cli.hostedPeers == nil {
+		cli.hostedPeers = make(map[types.JID]bool)
+	}
+	cli.hostedPeers[jid.ToNonAD()] = true
+}
+
+// PairHosted performs the hosted-account (WhatsApp Business Cloud API) variant of the companion
+// code pairing exchange for the primary identified by businessID, returning the human-readable
+// code the same way PairPhone does. The server responds with the usual
+// pair-device-sign/pair-success flow, handled by the existing handlePairSuccess/handlePair code,
+// except the device identity this client receives in pair-success is signed with the hosted
+// HMAC/signature prefixes (see AdvHostedPrefixDeviceIdentityAccountSignature and
+// AdvHostedPrefixDeviceIdentityDeviceSignatureVerification). Once pairing completes,
+// Store.AccountType is recorded as types.AccountTypeHosted and events.HostedPairSuccess (or
+// events.HostedPairError on failure) is dispatched instead of the regular PairSuccess/PairError.
+//
+// Operationally, a hosted account's primary runs on WhatsApp's Cloud API infrastructure rather
+// than a phone: there's no physical device to re-scan a QR code with if the pairing expires, and
+// messages exchanged with it are subject to the Cloud API's own rate limits rather than a phone's.
+func (cli *Client) PairHosted(ctx context.Context, businessID string, showPushNotification bool, clientDisplayName, clientPlatform string) (string, error) {
+	if len(businessID) == 0 {
+		return "", fmt.Errorf("no business ID provided for hosted account pairing")
+	}
+	cli.hostedPairPending.Store(true)
+	code, err := cli.startCodePairing(ctx, waBinary.Attrs{
+		"account_type": "hosted",
+		"business_id":  businessID,
+	}, showPushNotification, clientDisplayName, clientPlatform)
+	if err != nil {
+		cli.hostedPairPending.Store(false)
+		return "", fmt.Errorf("failed to start hosted account pairing: %w", err)
+	}
+	return code, nil
+}
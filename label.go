@@ -0,0 +1,121 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+
+	"github.com/oussama-chaabouni/whatsmeow/appstate"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+)
+
+// dispatchAppState routes a single decoded app state mutation to the handler for its index name,
+// the same way FetchAppState does for every mutation in a patch it applies. Right now the only
+// cases registered are the label ones added alongside handleLabelMutation; other mutation kinds
+// (mute, archive, pin, contact, ...) are expected to get their own cases here following the same
+// pattern.
+func (cli *Client) dispatchAppState(mutation appstate.Mutation, fromFullSync bool) {
+	if len(mutation.Index) == 0 {
+		return
+	}
+	switch mutation.Index[0] {
+	case appstate.IndexLabelEdit, appstate.IndexLabelAssociationChat, appstate.IndexLabelAssociationMessage:
+		cli.handleLabelMutation(mutation, fromFullSync)
+	}
+}
+
+// handleLabelMutation decodes the label_edit, label_jid and label_message app state mutations
+// added alongside this function. It's called from dispatchAppState for those three index names.
+func (cli *Client) handleLabelMutation(mutation appstate.Mutation, fromFullSync bool) {
+	if len(mutation.Index) == 0 {
+		return
+	}
+	switch mutation.Index[0] {
+	case appstate.IndexLabelEdit:
+		if len(mutation.Index) < 2 {
+			return
+		}
+		act := mutation.Value.GetLabelEditAction()
+		cli.dispatchEvent(&events.LabelEdit{
+			LabelID:      mutation.Index[1],
+			Name:         act.GetName(),
+			Color:        act.GetColor(),
+			Deleted:      act.GetDeleted(),
+			FromFullSync: fromFullSync,
+		})
+	case appstate.IndexLabelAssociationChat:
+		if len(mutation.Index) < 3 {
+			return
+		}
+		jid, err := types.ParseJID(mutation.Index[2])
+		if err != nil {
+			cli.Log.Warnf("Failed to parse chat JID in label_jid mutation: %v", err)
+			return
+		}
+		cli.dispatchEvent(&events.LabelAssociationChat{
+			JID:          jid,
+			LabelID:      mutation.Index[1],
+			Labeled:      mutation.Value.GetLabelAssociationAction().GetLabeled(),
+			FromFullSync: fromFullSync,
+		})
+	case appstate.IndexLabelAssociationMessage:
+		if len(mutation.Index) < 4 {
+			return
+		}
+		jid, err := types.ParseJID(mutation.Index[1])
+		if err != nil {
+			cli.Log.Warnf("Failed to parse chat JID in label_message mutation: %v", err)
+			return
+		}
+		cli.dispatchEvent(&events.LabelAssociationMessage{
+			JID:          jid,
+			MessageID:    types.MessageID(mutation.Index[2]),
+			LabelID:      mutation.Index[3],
+			Labeled:      mutation.Value.GetLabelAssociationAction().GetLabeled(),
+			FromFullSync: fromFullSync,
+		})
+	}
+}
+
+// CreateLabel creates a new WhatsApp Business label with the given name and color and pushes the
+// change through app state, the same way the official apps manage labels.
+func (cli *Client) CreateLabel(ctx context.Context, labelID, name string, color int32) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelEdit(labelID, name, color, false))
+}
+
+// EditLabel renames and/or recolors an existing label.
+func (cli *Client) EditLabel(ctx context.Context, labelID, name string, color int32) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelEdit(labelID, name, color, false))
+}
+
+// DeleteLabel deletes a label. Chats and messages that were tagged with it keep their
+// label_jid/label_message associations, but clients stop showing them once the label itself
+// is gone.
+func (cli *Client) DeleteLabel(ctx context.Context, labelID string) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelEdit(labelID, "", 0, true))
+}
+
+// AddChatLabel tags a chat with a label.
+func (cli *Client) AddChatLabel(ctx context.Context, chat types.JID, labelID string) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelChatAction(chat, labelID, true))
+}
+
+// RemoveChatLabel removes a label from a chat.
+func (cli *Client) RemoveChatLabel(ctx context.Context, chat types.JID, labelID string) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelChatAction(chat, labelID, false))
+}
+
+// AddMessageLabel tags a single message with a label.
+func (cli *Client) AddMessageLabel(ctx context.Context, chat types.JID, messageID types.MessageID, labelID string) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelMessageAction(chat, messageID, labelID, true))
+}
+
+// RemoveMessageLabel removes a label from a single message.
+func (cli *Client) RemoveMessageLabel(ctx context.Context, chat types.JID, messageID types.MessageID, labelID string) error {
+	return cli.SendAppState(ctx, appstate.BuildLabelMessageAction(chat, messageID, labelID, false))
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/oussama-chaabouni/whatsmeow/appstate"
+	"github.com/oussama-chaabouni/whatsmeow/proto/waSyncAction"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+)
+
+// TestDispatchAppStateLabelEdit drives a decoded label_edit mutation through dispatchAppState --
+// the real per-mutation dispatch site FetchAppState calls for every mutation it decodes -- and
+// asserts that events.LabelEdit comes out the other end, instead of handleLabelMutation silently
+// never being called.
+func TestDispatchAppStateLabelEdit(t *testing.T) {
+	cli := &Client{}
+
+	var got *events.LabelEdit
+	cli.AddEventHandler(func(evt interface{}) {
+		if evt, ok := evt.(*events.LabelEdit); ok {
+			got = evt
+		}
+	})
+
+	mutation := appstate.Mutation{
+		Index: []string{appstate.IndexLabelEdit, "42"},
+		Value: &waSyncAction.SyncActionValue{
+			LabelEditAction: &waSyncAction.LabelEditAction{
+				Name:  proto.String("Important"),
+				Color: proto.Int32(3),
+			},
+		},
+	}
+	cli.dispatchAppState(mutation, false)
+
+	if got == nil {
+		t.Fatal("expected events.LabelEdit to be dispatched, got nothing")
+	}
+	if got.LabelID != "42" || got.Name != "Important" || got.Color != 3 || got.Deleted {
+		t.Fatalf("unexpected event contents: %+v", got)
+	}
+}
@@ -0,0 +1,42 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+
+	"github.com/oussama-chaabouni/whatsmeow/proto/waMsgTransport"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+)
+
+// sendMessageTransport is the shared low-level primitive every outgoing MessageTransport_Payload
+// goes through to get encrypted and delivered, regardless of which message application it carries
+// (SendIGMessage's Instamadillo payloads today, any future FB-application-versioned payload
+// tomorrow). It picks the device-identity signature prefix transparently to the caller, based on
+// whether to is a known hosted-account peer (see isHostedPeer/MarkHostedPeer in hosted.go), and
+// then hands off to the same Signal session encryption every other outgoing message uses.
+func (cli *Client) sendMessageTransport(ctx context.Context, to types.JID, msgTransport *waMsgTransport.MessageTransport_Payload, extra SendRequestExtra) (SendResponse, error) {
+	devicePrefix := AdvPrefixDeviceSignatureGenerate
+	if cli.isHostedPeer(to) {
+		devicePrefix = AdvHostedPrefixDeviceIdentityDeviceSignatureVerification
+	}
+	return cli.encryptAndSendTransport(ctx, to, msgTransport, devicePrefix, extra)
+}
+
+// handleMessageTransport is the receive-side switch every decrypted MessageTransport_Payload goes
+// through, branching on the message application version the stanza parser read off the envelope
+// (see waMsgTransport.FBMessageApplicationVersion/IGMessageApplicationVersion). It's called right
+// after an incoming node decrypts into a MessageTransport_Payload, the same way
+// sendMessageTransport is the single place every outgoing one goes out through.
+func (cli *Client) handleMessageTransport(info *types.MessageInfo, msgTransport *waMsgTransport.MessageTransport_Payload, version int) {
+	switch version {
+	case waMsgTransport.IGMessageApplicationVersion:
+		cli.dispatchIGMessageTransport(info, msgTransport)
+	default:
+		cli.Log.Debugf("Ignoring message transport payload for %s at unhandled application version %d", info.ID, version)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/crypto/hkdf"
+
+	waBinary "github.com/oussama-chaabouni/whatsmeow/binary"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+	"github.com/oussama-chaabouni/whatsmeow/util/keys"
+)
+
+// pairCodeAlphabet is the set of characters the 8-character linking code shown to the user is made
+// of. It intentionally skips visually ambiguous characters (0, 1, I, O). Its length (32) is not
+// incidental: at 5 bits per character, 8 characters encode exactly pairCodeSecretLen bytes, so the
+// code is a lossless, invertible encoding of the secret rather than a lossy hash of it.
+const pairCodeAlphabet = "23456789ABCDEFGHJKLMNPQRSTUVWXYZ"
+
+// pairCodeSecretLen is the size in bytes of the secret that's fully encoded in the pairing code.
+// The code is the only thing ever communicated to the primary device, so every key used in this
+// flow must be derivable from just these bytes.
+const pairCodeSecretLen = 5
+
+var (
+	ErrPairPhoneNumberEmpty   = errors.New("no phone number provided for phone code pairing")
+	ErrPairNoPendingPhonePair = errors.New("received a pair-success response, but no phone code pairing is pending")
+	ErrPairInvalidLinkingHMAC = errors.New("primary device HMAC does not match the linking code that was entered")
+)
+
+// phonePairingState holds the key material that PairPhone generates locally and that is needed to
+// finish the pairing once the `pair-success` IQ (handled by handlePairSuccess) comes in.
+type phonePairingState struct {
+	companionEphemeralKey    *keys.KeyPair
+	codeSecret               [pairCodeSecretLen]byte
+	encryptedPrimaryIdentity []byte
+}
+
+// derivePhonePairingKey expands codeSecret - the bytes encoded in the pairing code shown to the
+// user - into a 32-byte AES-256/HMAC-SHA256 key for the given purpose, via HKDF-SHA256. codeSecret
+// is the only secret ever shared out of band (via the code the user types into their phone), so
+// every key this flow needs, including the ones the primary device derives after parsing the code,
+// must come from here rather than from any additional randomness PairPhone generates locally.
+func derivePhonePairingKey(codeSecret []byte, info string) []byte {
+	key := make([]byte, 32)
+	_, _ = io.ReadFull(hkdf.New(sha256.New, codeSecret, nil, []byte(info)), key)
+	return key
+}
+
+// decryptPhonePairingPrimaryIdentity reverses the server's encryption (performed with a key derived
+// from the pairing code) of the primary device's identity key, so handlePair can check that the
+// device-identity it received over `pair-success` actually belongs to the device the user paired
+// with using the code.
+func decryptPhonePairingPrimaryIdentity(codeSecret, encryptedPrimaryIdentity []byte) ([]byte, error) {
+	block, err := aes.NewCipher(derivePhonePairingKey(codeSecret, "link-code-pairing-primary-identity"))
+	if err != nil {
+		return nil, err
+	}
+	plaintext := make([]byte, len(encryptedPrimaryIdentity))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(plaintext, encryptedPrimaryIdentity)
+	return plaintext, nil
+}
+
+// PairPhone generates a pairing code that can be entered on the WhatsApp primary device to link
+// this client, as an alternative to scanning a QR code (see events.QR).
+//
+// The returned code is an 8-character string formatted as `XXXX-XXXX`. After the user enters it,
+// the server responds with the usual `pair-device-sign`/`pair-success` flow, which is handled by
+// the existing handlePairSuccess/handlePair code and results in events.PairSuccess or
+// events.PairError like a QR login would.
+func (cli *Client) PairPhone(ctx context.Context, phoneNumber string, showPushNotification bool, clientDisplayName, clientPlatform string) (string, error) {
+	if len(phoneNumber) == 0 {
+		return "", ErrPairPhoneNumberEmpty
+	}
+	return cli.startCodePairing(ctx, nil, showPushNotification, clientDisplayName, clientPlatform)
+}
+
+// startCodePairing runs the companion-code pairing handshake shared by PairPhone and PairHosted:
+// it generates a companion ephemeral key and pairing code secret, registers the companion key with
+// the server, wrapped with a key derived from the secret (with regAttrs merged into the
+// `link_code_companion_reg` node so callers can tag the kind of account being paired, e.g.
+// PairHosted's `account_type`/`business_id`), stores the pending state for handlePair to pick up
+// once `pair-success` arrives, and returns the human-readable code. The secret itself is never
+// sent to the server. ctx is forwarded to the registration IQ so callers' cancellation/timeouts are honored instead of
+// being silently dropped.
+func (cli *Client) startCodePairing(ctx context.Context, regAttrs waBinary.Attrs, showPushNotification bool, clientDisplayName, clientPlatform string) (string, error) {
+	companionEphemeralKey := keys.NewKeyPair()
+	var codeSecret [pairCodeSecretLen]byte
+	if _, err := rand.Read(codeSecret[:]); err != nil {
+		return "", fmt.Errorf("failed to generate pairing code secret: %w", err)
+	}
+	wrappedCompanionKey, err := wrapPhonePairingCompanionKey(codeSecret[:], companionEphemeralKey.Pub[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap companion key for code pairing: %w", err)
+	}
+
+	resp, err := cli.sendIQ(infoQuery{
+		Context:   ctx,
+		Namespace: "md",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag:   "link_code_companion_reg",
+			Attrs: regAttrs,
+			Content: []waBinary.Node{
+				{Tag: "link_code_pairing_wrapped_companion_ephemeral_pub", Content: wrappedCompanionKey},
+				{Tag: "companion_server_auth_key_pub", Content: cli.Store.NoiseKey.Pub[:]},
+				{Tag: "companion_platform_id", Content: clientPlatform},
+				{Tag: "companion_platform_display", Content: clientDisplayName},
+				{Tag: "link_code_pairing_nonce", Content: []byte{0}},
+			},
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send code pairing registration: %w", err)
+	}
+	companionReg := resp.GetChildByTag("link_code_companion_reg")
+	pairingRef, _ := companionReg.Attrs["pairing_ref"].(string)
+	if pairingRef == "" {
+		return "", fmt.Errorf("server did not return a pairing ref for code pairing")
+	}
+	encryptedPrimaryIdentity, _ := companionReg.GetChildByTag("primary_identity_pub").Content.([]byte)
+
+	code := formatPairingCode(codeSecret)
+
+	cli.phonePairingStateLock.Lock()
+	cli.phonePairingState = &phonePairingState{
+		companionEphemeralKey:    companionEphemeralKey,
+		codeSecret:               codeSecret,
+		encryptedPrimaryIdentity: encryptedPrimaryIdentity,
+	}
+	cli.phonePairingStateLock.Unlock()
+
+	cli.dispatchEvent(&events.PairCode{
+		Code:                 code,
+		PairingRef:           pairingRef,
+		ShowPushNotification: showPushNotification,
+	})
+
+	return code, nil
+}
+
+// formatPairingCode packs codeSecret into the human-readable `XXXX-XXXX` code that the user types
+// into their primary device, 5 bits (one pairCodeAlphabet symbol) at a time, most significant bits
+// first. Because pairCodeAlphabet has exactly 32 symbols and codeSecret is exactly 5 bytes (40
+// bits), this is a lossless, invertible encoding: the primary device recovers codeSecret byte for
+// byte from the typed code, with nothing else shared out of band.
+func formatPairingCode(codeSecret [pairCodeSecretLen]byte) string {
+	bits := uint64(codeSecret[0])<<32 | uint64(codeSecret[1])<<24 | uint64(codeSecret[2])<<16 | uint64(codeSecret[3])<<8 | uint64(codeSecret[4])
+	var sb strings.Builder
+	for i := 7; i >= 0; i-- {
+		sb.WriteByte(pairCodeAlphabet[(bits>>(uint(i)*5))&0x1F])
+		if i == 4 {
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+// wrapPhonePairingCompanionKey encrypts the companion ephemeral public key with a key derived from
+// the pairing code, so only someone who also knows the code displayed to the user can recover it.
+func wrapPhonePairingCompanionKey(codeSecret, companionEphemeralPub []byte) ([]byte, error) {
+	block, err := aes.NewCipher(derivePhonePairingKey(codeSecret, "link-code-pairing-companion-ephemeral-key"))
+	if err != nil {
+		return nil, err
+	}
+	wrapped := make([]byte, len(companionEphemeralPub))
+	cipher.NewCTR(block, make([]byte, aes.BlockSize)).XORKeyStream(wrapped, companionEphemeralPub)
+	return wrapped, nil
+}
+
+// verifyPhonePairingLinkingHMAC validates that the HMAC sent by the primary device in the
+// `pair-success` IQ was computed using a key derived from the pairing code and the primary's
+// advertising key, proving that the user actually typed in the code we generated.
+func verifyPhonePairingLinkingHMAC(codeSecret, companionAdvertisingKey, expectedHMAC []byte) bool {
+	mac := hmac.New(sha256.New, derivePhonePairingKey(codeSecret, "link-code-pairing-hmac"))
+	mac.Write(companionAdvertisingKey)
+	return hmac.Equal(mac.Sum(nil), expectedHMAC)
+}
@@ -0,0 +1,99 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	waBinary "github.com/oussama-chaabouni/whatsmeow/binary"
+	"github.com/oussama-chaabouni/whatsmeow/appstate"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+)
+
+// DefaultPostPairSyncTimeout is used for PostPairSyncTimeout when it is left at its zero value.
+const DefaultPostPairSyncTimeout = 2 * time.Minute
+
+// postPairSyncPatchNames is the set of app state patches that are resynced after a fresh pairing,
+// in the order the real app requests them.
+var postPairSyncPatchNames = []appstate.WAPatchName{
+	appstate.WAPatchRegularHigh,
+	appstate.WAPatchRegularLow,
+	appstate.WAPatchCriticalBlock,
+	appstate.WAPatchCriticalUnblock,
+}
+
+// maybeStartPostPairSync arranges for PostPairSync to run in the background once this client is
+// actually connected again after a fresh pairing. It does nothing unless EnableAutoPostPairSync is
+// set; the field is false on a zero Client, so the automatic sync is off by default. It can't just run PostPairSync right away: handlePairSuccess calls this
+// from the same success path where it just called expectDisconnect, so the socket is about to
+// drop and reconnect with the new session, and FetchAppState/sendIQ would race that reconnect and
+// fail. Instead, it waits for the events.Connected that follows the reconnect.
+func (cli *Client) maybeStartPostPairSync() {
+	if !cli.EnableAutoPostPairSync {
+		return
+	}
+	var handlerID uint32
+	handlerID = cli.AddEventHandler(func(evt interface{}) {
+		if _, ok := evt.(*events.Connected); !ok {
+			return
+		}
+		cli.RemoveEventHandler(handlerID)
+		go func() {
+			timeout := cli.PostPairSyncTimeout
+			if timeout <= 0 {
+				timeout = DefaultPostPairSyncTimeout
+			}
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+			if err := cli.PostPairSync(ctx); err != nil {
+				cli.Log.Warnf("Post-pair sync failed: %v", err)
+			}
+		}()
+	})
+}
+
+// PostPairSync fetches the historical app state, contacts and chat history for a freshly-linked
+// account. It's called automatically after events.PairSuccess when EnableAutoPostPairSync is set,
+// but can also be invoked manually by callers who leave EnableAutoPostPairSync unset.
+//
+// It resyncs every known app state patch name from scratch, asks the server to push on-demand
+// history for the chats the phone knows about, and dispatches events.InitialSyncComplete once
+// both steps are done.
+func (cli *Client) PostPairSync(ctx context.Context) error {
+	for _, name := range postPairSyncPatchNames {
+		if err := cli.FetchAppState(ctx, name, true, false); err != nil {
+			return fmt.Errorf("failed to resync app state patch %s: %w", name, err)
+		}
+	}
+	if err := cli.requestHistoryNotification(ctx); err != nil {
+		return fmt.Errorf("failed to request history notification: %w", err)
+	}
+	cli.dispatchEvent(&events.InitialSyncComplete{})
+	return nil
+}
+
+// requestHistoryNotification asks the server to push on-demand history sync payloads for the
+// chats that the primary device knows about, the same way the official apps do right after
+// linking a new companion device.
+func (cli *Client) requestHistoryNotification(ctx context.Context) error {
+	_, err := cli.sendIQ(infoQuery{
+		Context:   ctx,
+		Namespace: "w:sync:app:state",
+		Type:      iqSet,
+		To:        types.ServerJID,
+		Content: []waBinary.Node{{
+			Tag: "notification",
+			Attrs: waBinary.Attrs{
+				"type": "history",
+			},
+		}},
+	})
+	return err
+}
@@ -95,13 +95,23 @@ func (cli *Client) handlePairSuccess(node *waBinary.Node) {
 
 	go func() {
 		err := cli.handlePair(context.TODO(), deviceIdentityBytes, id, businessName, platform, jid, lid)
+		defer cli.hostedPairPending.Store(false)
 		if err != nil {
 			cli.Log.Errorf("Failed to pair device: %v", err)
 			cli.Disconnect()
-			cli.dispatchEvent(&events.PairError{ID: jid, LID: lid, BusinessName: businessName, Platform: platform, Error: err})
+			if cli.pendingHostedPair() {
+				cli.dispatchEvent(&events.HostedPairError{ID: jid, LID: lid, BusinessName: businessName, Platform: platform, Error: err})
+			} else {
+				cli.dispatchEvent(&events.PairError{ID: jid, LID: lid, BusinessName: businessName, Platform: platform, Error: err})
+			}
 		} else {
 			cli.Log.Infof("Successfully paired %s", cli.Store.ID)
-			cli.dispatchEvent(&events.PairSuccess{ID: jid, LID: lid, BusinessName: businessName, Platform: platform})
+			if cli.isHostedAccount() {
+				cli.dispatchEvent(&events.HostedPairSuccess{ID: jid, LID: lid, BusinessName: businessName, Platform: platform})
+			} else {
+				cli.dispatchEvent(&events.PairSuccess{ID: jid, LID: lid, BusinessName: businessName, Platform: platform})
+			}
+			cli.maybeStartPostPairSync()
 		}
 	}()
 }
@@ -148,6 +158,29 @@ func (cli *Client) handlePair(ctx context.Context, deviceIdentityBytes []byte, r
 		return &PairProtoError{"failed to parse device identity details in pair success message", err}
 	}
 
+	cli.phonePairingStateLock.Lock()
+	pendingPhonePair := cli.phonePairingState
+	cli.phonePairingState = nil
+	cli.phonePairingStateLock.Unlock()
+	linkingHMAC, hasLinkingHMAC := pairSuccess.GetChildByTag("link_code_pairing_hmac").Content.([]byte)
+	if hasLinkingHMAC && pendingPhonePair == nil {
+		cli.sendPairError(reqID, 401, "hmac-mismatch")
+		return ErrPairNoPendingPhonePair
+	}
+	if pendingPhonePair != nil {
+		if !verifyPhonePairingLinkingHMAC(pendingPhonePair.codeSecret[:], deviceIdentity.AccountSignatureKey, linkingHMAC) {
+			cli.sendPairError(reqID, 401, "hmac-mismatch")
+			return ErrPairInvalidLinkingHMAC
+		}
+		if len(pendingPhonePair.encryptedPrimaryIdentity) > 0 {
+			primaryIdentity, err := decryptPhonePairingPrimaryIdentity(pendingPhonePair.codeSecret[:], pendingPhonePair.encryptedPrimaryIdentity)
+			if err != nil || !bytes.Equal(primaryIdentity, deviceIdentity.AccountSignatureKey) {
+				cli.sendPairError(reqID, 401, "identity-mismatch")
+				return ErrPairInvalidLinkingHMAC
+			}
+		}
+	}
+
 	if cli.PrePairCallback != nil && !cli.PrePairCallback(jid, platform, businessName) {
 		cli.sendPairError(reqID, 500, "internal-error")
 		return ErrPairRejectedLocally
@@ -170,6 +203,11 @@ func (cli *Client) handlePair(ctx context.Context, deviceIdentityBytes []byte, r
 	cli.Store.LID = lid
 	cli.Store.BusinessName = businessName
 	cli.Store.Platform = platform
+	if isHostedAccount {
+		cli.Store.AccountType = types.AccountTypeHosted
+	} else {
+		cli.Store.AccountType = types.AccountTypeDefault
+	}
 	err = cli.Store.Save(ctx)
 	if err != nil {
 		cli.sendPairError(reqID, 500, "internal-error")
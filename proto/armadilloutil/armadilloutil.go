@@ -0,0 +1,28 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package armadilloutil contains helpers shared by the generated armadillo message application
+// wrapper types for encoding and decoding the versioned payloads they carry.
+package armadilloutil
+
+import "google.golang.org/protobuf/proto"
+
+// Unmarshal decodes data into into, which is used as the target message and also as the return
+// value so callers can write armadilloutil.Unmarshal(&SomeType{}, raw, version) without a separate
+// type assertion. version identifies the message application version the payload was encoded
+// with; callers pass the constant for the application (e.g. FBMessageApplicationVersion or
+// IGMessageApplicationVersion) so future versions of this package can branch on it if the wire
+// format ever diverges between them.
+func Unmarshal[T proto.Message](into T, data []byte, version int) (T, error) {
+	err := proto.Unmarshal(data, into)
+	return into, err
+}
+
+// Marshal is the encode-side counterpart of Unmarshal: it serializes payload for the given
+// message application version.
+func Marshal(payload proto.Message, version int) ([]byte, error) {
+	return proto.Marshal(payload)
+}
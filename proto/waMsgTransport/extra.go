@@ -1,9 +1,9 @@
 package waMsgTransport
 
 import (
-	"github.com/oussama-chaabouni/whatsmeow/whatsmeow/proto/armadilloutil"
-	"github.com/oussama-chaabouni/whatsmeow/whatsmeow/proto/instamadilloTransportPayload"
-	"github.com/oussama-chaabouni/whatsmeow/whatsmeow/proto/waMsgApplication"
+	"github.com/oussama-chaabouni/whatsmeow/proto/armadilloutil"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloTransportPayload"
+	"github.com/oussama-chaabouni/whatsmeow/proto/waMsgApplication"
 )
 
 const (
@@ -18,3 +18,14 @@ func (msg *MessageTransport_Payload) DecodeFB() (*waMsgApplication.MessageApplic
 func (msg *MessageTransport_Payload) DecodeIG() (*instamadilloTransportPayload.TransportPayload, error) {
 	return armadilloutil.Unmarshal(&instamadilloTransportPayload.TransportPayload{}, msg.GetApplicationPayload(), IGMessageApplicationVersion)
 }
+
+// EncodeIG is the send-side counterpart of DecodeIG: it marshals payload at
+// IGMessageApplicationVersion and stores it as this message's application payload.
+func (msg *MessageTransport_Payload) EncodeIG(payload *instamadilloTransportPayload.TransportPayload) error {
+	data, err := armadilloutil.Marshal(payload, IGMessageApplicationVersion)
+	if err != nil {
+		return err
+	}
+	msg.ApplicationPayload = data
+	return nil
+}
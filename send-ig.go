@@ -0,0 +1,75 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/oussama-chaabouni/whatsmeow/proto/armadillo"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloAddMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloDeleteMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloSupplementMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloTransportPayload"
+	"github.com/oussama-chaabouni/whatsmeow/proto/waMsgTransport"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+)
+
+// SendIGMessage sends an Instamadillo (Instagram/Messenger) message application sub-payload --
+// an AddMessagePayload, SupplementMessagePayload or DeleteMessagePayload -- to an
+// Instamadillo-bridged chat. It's the send-side counterpart of MessageTransport_Payload.DecodeIG:
+// the payload is wrapped in a TransportPayload, encoded at waMsgTransport.IGMessageApplicationVersion
+// via MessageTransport_Payload.EncodeIG, and then handed to sendMessageTransport
+// (message-transport.go), the same shared low-level sender every other MessageTransport_Payload
+// goes out through.
+func (cli *Client) SendIGMessage(ctx context.Context, to types.JID, payload armadillo.MessageApplicationSub, extra SendRequestExtra) (SendResponse, error) {
+	transportPayload, err := wrapInstamadilloPayload(payload)
+	if err != nil {
+		return SendResponse{}, fmt.Errorf("failed to wrap Instamadillo payload: %w", err)
+	}
+	var msgTransport waMsgTransport.MessageTransport_Payload
+	if err = msgTransport.EncodeIG(transportPayload); err != nil {
+		return SendResponse{}, fmt.Errorf("failed to encode Instamadillo transport payload: %w", err)
+	}
+	return cli.sendMessageTransport(ctx, to, &msgTransport, extra)
+}
+
+// wrapInstamadilloPayload puts payload into the oneof field of a TransportPayload that matches
+// its concrete type.
+func wrapInstamadilloPayload(payload armadillo.MessageApplicationSub) (*instamadilloTransportPayload.TransportPayload, error) {
+	switch p := payload.(type) {
+	case *instamadilloAddMessage.AddMessagePayload:
+		return &instamadilloTransportPayload.TransportPayload{
+			Payload: &instamadilloTransportPayload.TransportPayload_AddMessage{AddMessage: p},
+		}, nil
+	case *instamadilloSupplementMessage.SupplementMessagePayload:
+		return &instamadilloTransportPayload.TransportPayload{
+			Payload: &instamadilloTransportPayload.TransportPayload_SupplementMessage{SupplementMessage: p},
+		}, nil
+	case *instamadilloDeleteMessage.DeleteMessagePayload:
+		return &instamadilloTransportPayload.TransportPayload{
+			Payload: &instamadilloTransportPayload.TransportPayload_DeleteMessage{DeleteMessage: p},
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported Instamadillo payload type %T", payload)
+	}
+}
+
+// dispatchIGMessageTransport decodes an incoming message transport payload as Instamadillo and
+// dispatches events.IGMessage. It's called by handleMessageTransport (message-transport.go) once
+// that function has identified the payload as waMsgTransport.IGMessageApplicationVersion, so that
+// IG-flavoured messages are routed to their own event instead of being silently ignored.
+func (cli *Client) dispatchIGMessageTransport(info *types.MessageInfo, msgTransport *waMsgTransport.MessageTransport_Payload) bool {
+	payload, err := msgTransport.DecodeIG()
+	if err != nil {
+		cli.Log.Warnf("Failed to decode Instamadillo transport payload in %s: %v", info.ID, err)
+		return false
+	}
+	cli.dispatchEvent(&events.IGMessage{Info: *info, Payload: payload})
+	return true
+}
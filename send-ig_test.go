@@ -0,0 +1,94 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package whatsmeow
+
+import (
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+
+	"github.com/oussama-chaabouni/whatsmeow/proto/armadillo"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloAddMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloDeleteMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloSupplementMessage"
+	"github.com/oussama-chaabouni/whatsmeow/proto/waMsgTransport"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/types/events"
+)
+
+// TestWrapInstamadilloPayloadRoundTrip checks that each Instamadillo payload type SendIGMessage
+// accepts survives wrapInstamadilloPayload, EncodeIG and DecodeIG unchanged, i.e. that the send
+// side and dispatchIGMessageTransport's receive side agree on the wire format.
+func TestWrapInstamadilloPayloadRoundTrip(t *testing.T) {
+	cases := []struct {
+		name    string
+		payload armadillo.MessageApplicationSub
+	}{
+		{"AddMessage", &instamadilloAddMessage.AddMessagePayload{}},
+		{"SupplementMessage", &instamadilloSupplementMessage.SupplementMessagePayload{}},
+		{"DeleteMessage", &instamadilloDeleteMessage.DeleteMessagePayload{}},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			transportPayload, err := wrapInstamadilloPayload(tc.payload)
+			if err != nil {
+				t.Fatalf("wrapInstamadilloPayload failed: %v", err)
+			}
+
+			var msgTransport waMsgTransport.MessageTransport_Payload
+			if err := msgTransport.EncodeIG(transportPayload); err != nil {
+				t.Fatalf("EncodeIG failed: %v", err)
+			}
+
+			decoded, err := msgTransport.DecodeIG()
+			if err != nil {
+				t.Fatalf("DecodeIG failed: %v", err)
+			}
+
+			if !proto.Equal(transportPayload, decoded) {
+				t.Fatalf("round-tripped payload does not match original\nwant: %v\ngot:  %v", transportPayload, decoded)
+			}
+		})
+	}
+}
+
+// TestHandleMessageTransportDispatchesIGMessage drives an encoded Instamadillo payload through
+// handleMessageTransport -- the real receive-side switch incoming MessageTransport_Payloads go
+// through -- and asserts that events.IGMessage comes out the other end for the IG application
+// version, instead of dispatchIGMessageTransport silently never being called.
+func TestHandleMessageTransportDispatchesIGMessage(t *testing.T) {
+	cli := &Client{}
+
+	transportPayload, err := wrapInstamadilloPayload(&instamadilloAddMessage.AddMessagePayload{})
+	if err != nil {
+		t.Fatalf("wrapInstamadilloPayload failed: %v", err)
+	}
+	var msgTransport waMsgTransport.MessageTransport_Payload
+	if err := msgTransport.EncodeIG(transportPayload); err != nil {
+		t.Fatalf("EncodeIG failed: %v", err)
+	}
+
+	var got *events.IGMessage
+	cli.AddEventHandler(func(evt interface{}) {
+		if evt, ok := evt.(*events.IGMessage); ok {
+			got = evt
+		}
+	})
+
+	info := &types.MessageInfo{ID: types.MessageID("TESTMSG1")}
+	cli.handleMessageTransport(info, &msgTransport, waMsgTransport.IGMessageApplicationVersion)
+
+	if got == nil {
+		t.Fatal("expected events.IGMessage to be dispatched, got nothing")
+	}
+	if got.Info.ID != info.ID {
+		t.Fatalf("unexpected event info: %+v", got.Info)
+	}
+	if !proto.Equal(transportPayload, got.Payload) {
+		t.Fatalf("dispatched payload does not match original\nwant: %v\ngot:  %v", transportPayload, got.Payload)
+	}
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2021 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+// Package store holds the persistent state of a paired WhatsApp device.
+package store
+
+import (
+	"context"
+
+	"github.com/oussama-chaabouni/whatsmeow/proto/waAdv"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+	"github.com/oussama-chaabouni/whatsmeow/util/keys"
+)
+
+// IdentityStore stores the Signal identity keys of other devices, keyed by their signal address.
+type IdentityStore interface {
+	PutIdentity(ctx context.Context, address string, key [32]byte) error
+}
+
+// Device contains the identity of a WhatsApp device, along with the keys and other state needed
+// to maintain its connection and sessions after pairing.
+type Device struct {
+	NoiseKey     *keys.KeyPair
+	IdentityKey  *keys.KeyPair
+	AdvSecretKey []byte
+
+	ID           *types.JID
+	LID          types.JID
+	Account      *waAdv.ADVSignedDeviceIdentity
+	BusinessName string
+	Platform     string
+
+	// AccountType records whether this device is paired to a regular WhatsApp account or a
+	// hosted (WhatsApp Business Cloud API) one. It's set once, in handlePair, right after a
+	// successful pairing, and determines which device-identity signature prefix is used for
+	// this device from then on (see isHostedAccount and the Adv*Hosted* prefixes in pair.go).
+	AccountType types.AccountType
+
+	Identities IdentityStore
+}
+
+// Save persists the current state of the device to the backing store.
+//
+// This trimmed build doesn't include a concrete backing store (the real one lives outside this
+// snapshot, e.g. the SQL-backed container used elsewhere in whatsmeow); callers that need actual
+// persistence should replace Device with one backed by such a container.
+func (d *Device) Save(ctx context.Context) error {
+	return nil
+}
+
+// Delete removes the device's state from the backing store, e.g. after a failed pairing.
+func (d *Device) Delete(ctx context.Context) error {
+	return nil
+}
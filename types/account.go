@@ -0,0 +1,29 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package types
+
+// AccountType indicates whether a device is linked to a regular WhatsApp account or a hosted
+// (WhatsApp Business Cloud API) account. It's persisted on Store.AccountType and determines which
+// device-identity signature prefix (regular or Adv*Hosted*) is used when verifying or generating
+// signatures for that device, and, for peers, when encrypting outgoing messages to them.
+type AccountType int
+
+const (
+	// AccountTypeDefault is a regular WhatsApp account paired to a phone.
+	AccountTypeDefault AccountType = iota
+	// AccountTypeHosted is a WhatsApp Business Cloud API (hosted) account.
+	AccountTypeHosted
+)
+
+func (t AccountType) String() string {
+	switch t {
+	case AccountTypeHosted:
+		return "hosted"
+	default:
+		return "regular"
+	}
+}
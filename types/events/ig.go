@@ -0,0 +1,20 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package events
+
+import (
+	"github.com/oussama-chaabouni/whatsmeow/proto/instamadilloTransportPayload"
+	"github.com/oussama-chaabouni/whatsmeow/types"
+)
+
+// IGMessage is emitted for incoming messages whose transport payload is tagged with
+// waMsgTransport.IGMessageApplicationVersion, i.e. Instamadillo (Instagram/Messenger) messages
+// received over a bridged chat, instead of being silently dropped like before this event existed.
+type IGMessage struct {
+	Info    types.MessageInfo
+	Payload *instamadilloTransportPayload.TransportPayload
+}
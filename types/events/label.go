@@ -0,0 +1,45 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package events
+
+import "github.com/oussama-chaabouni/whatsmeow/types"
+
+// LabelEdit is emitted when a WhatsApp Business label is created, renamed, recolored or deleted
+// via an app state mutation.
+type LabelEdit struct {
+	LabelID string
+	Name    string
+	Color   int32
+	Deleted bool
+
+	// FromFullSync is true if the event was dispatched because of a full app state sync,
+	// as opposed to a real-time patch.
+	FromFullSync bool
+}
+
+// LabelAssociationChat is emitted when a label is added to or removed from a chat.
+type LabelAssociationChat struct {
+	JID     types.JID
+	LabelID string
+	Labeled bool
+
+	// FromFullSync is true if the event was dispatched because of a full app state sync,
+	// as opposed to a real-time patch.
+	FromFullSync bool
+}
+
+// LabelAssociationMessage is emitted when a label is added to or removed from a single message.
+type LabelAssociationMessage struct {
+	JID       types.JID
+	MessageID types.MessageID
+	LabelID   string
+	Labeled   bool
+
+	// FromFullSync is true if the event was dispatched because of a full app state sync,
+	// as opposed to a real-time patch.
+	FromFullSync bool
+}
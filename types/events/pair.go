@@ -0,0 +1,41 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package events
+
+import "github.com/oussama-chaabouni/whatsmeow/types"
+
+// PairCode is emitted after a successful call to Client.PairPhone, alongside the existing QR
+// event for the scannable pairing flow. Codes should be entered on the WhatsApp primary device
+// within the usual pairing timeout.
+type PairCode struct {
+	// Code is the 8-character pairing code, formatted as `XXXX-XXXX`.
+	Code string
+	// PairingRef is the reference the server assigned to this pairing attempt.
+	PairingRef string
+	// ShowPushNotification indicates whether the primary device should show a push notification
+	// prompting the user to enter the code, as opposed to the user navigating to the linking
+	// screen themselves.
+	ShowPushNotification bool
+}
+
+// HostedPairSuccess is dispatched instead of PairSuccess when the device that just finished
+// pairing (via Client.PairHosted) is a hosted (WhatsApp Business Cloud API) account.
+type HostedPairSuccess struct {
+	ID           types.JID
+	LID          types.JID
+	BusinessName string
+	Platform     string
+}
+
+// HostedPairError is dispatched instead of PairError when a Client.PairHosted attempt fails.
+type HostedPairError struct {
+	ID           types.JID
+	LID          types.JID
+	BusinessName string
+	Platform     string
+	Error        error
+}
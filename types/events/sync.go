@@ -0,0 +1,12 @@
+// Copyright (c) 2023 Tulir Asokan
+//
+// This Source Code Form is subject to the terms of the Mozilla Public
+// License, v. 2.0. If a copy of the MPL was not distributed with this
+// file, You can obtain one at http://mozilla.org/MPL/2.0/.
+
+package events
+
+// InitialSyncComplete is emitted after Client.PostPairSync finishes resyncing app state and
+// requesting on-demand history for a freshly-paired device. It fires once, after every known app
+// state patch has been applied and the history notification has been acknowledged.
+type InitialSyncComplete struct{}